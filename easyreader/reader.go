@@ -0,0 +1,322 @@
+/* Package easyreader mirrors and extends the bufio.Reader interface, but
+delays error handling, the reading counterpart to easywriter.Writer.
+
+Instead of having to check the error on every call, you can read a few
+parts and then check for errors once you have completed a part.
+*/
+package easyreader
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+const (
+	defaultBufSize = 4096
+
+	// MaxVarintLen64 is the maximum length in bytes of a varint-encoded uint64.
+	MaxVarintLen64 = 10
+)
+
+// Reader is an io.Reader with many convenience methods that allow delayed
+// error checking.
+type Reader struct {
+	br  *bufio.Reader
+	err error
+	tmp [MaxVarintLen64]byte
+}
+
+// New constructs a Reader from an io.Reader. It wraps it in a bufio.Reader,
+// unless the passed in value already is a *bufio.Reader.
+func New(r io.Reader) *Reader {
+	if br, ok := r.(*bufio.Reader); ok {
+		// Optimization if r already is a bufio.Reader
+		return FromBufIOReader(br)
+	}
+	return NewSize(r, defaultBufSize)
+}
+
+// NewSize constructs a Reader from an io.Reader. It wraps it in a
+// bufio.Reader with given buffer size.
+func NewSize(r io.Reader, size int) *Reader {
+	return FromBufIOReader(bufio.NewReaderSize(r, size))
+}
+
+// FromBufIOReader constructs a Reader from a bufio.Reader.
+func FromBufIOReader(br *bufio.Reader) *Reader {
+	return &Reader{br: br}
+}
+
+// Err returns the current error, if any. Reading the error does not reset it.
+func (b *Reader) Err() error {
+	return b.err
+}
+
+// ResetErr resets the error to nil. You should never need this.
+func (b *Reader) ResetErr() {
+	b.err = nil
+}
+
+// AtEOF reports whether the latched error is exactly io.EOF, i.e. the
+// underlying reader was exhausted exactly at a value boundary. A read that
+// ran out of data partway through a multi-byte value latches
+// io.ErrUnexpectedEOF instead, for which AtEOF returns false.
+func (b *Reader) AtEOF() bool {
+	return errors.Is(b.err, io.EOF)
+}
+
+// bufio.Reader interface, but with error stripped
+
+// Size returns the size of the underlying buffer.
+func (b *Reader) Size() int {
+	return b.br.Size()
+}
+
+// Buffered returns the number of bytes that can be read from the current buffer.
+func (b *Reader) Buffered() int {
+	return b.br.Buffered()
+}
+
+// Read still returns an error, to keep satisfying the io.Reader interface.
+func (b *Reader) Read(p []byte) (n int, err error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	n, b.err = b.br.Read(p)
+	return n, b.err
+}
+
+// ReadByte reads and returns a single byte, without returning an error.
+func (b *Reader) ReadByte() byte {
+	if b.err != nil {
+		return 0
+	}
+	var c byte
+	c, b.err = b.br.ReadByte()
+	return c
+}
+
+// ReadRune reads a single UTF-8 encoded rune and returns the rune and its
+// size in bytes, without returning an error.
+func (b *Reader) ReadRune() (r rune, size int) {
+	if b.err != nil {
+		return 0, 0
+	}
+	r, size, b.err = b.br.ReadRune()
+	return r, size
+}
+
+// ReadFull reads exactly n bytes and returns them as a freshly allocated
+// slice. If the underlying reader runs out of data before n bytes could be
+// read, Err latches io.EOF if nothing at all could be read, or
+// io.ErrUnexpectedEOF if only part of it was read, same as io.ReadFull.
+func (b *Reader) ReadFull(n int) []byte {
+	if b.err != nil {
+		return nil
+	}
+	p := make([]byte, n)
+	_, b.err = io.ReadFull(b.br, p)
+	return p
+}
+
+// ReadString reads until the first occurrence of delim, returning a string
+// containing the data up to and including the delimiter. If the underlying
+// reader is exhausted before delim is found, ReadString returns the data
+// read so far and latches the error, usually io.EOF.
+func (b *Reader) ReadString(delim byte) string {
+	if b.err != nil {
+		return ""
+	}
+	var s string
+	s, b.err = b.br.ReadString(delim)
+	return s
+}
+
+// ReadLine is a low-level line-reading primitive mirroring
+// bufio.Reader.ReadLine: it returns the next line, without the trailing
+// end-of-line bytes. A very long line may be returned in chunks, indicated
+// by isPrefix.
+func (b *Reader) ReadLine() (line []byte, isPrefix bool) {
+	if b.err != nil {
+		return nil, false
+	}
+	line, isPrefix, b.err = b.br.ReadLine()
+	return line, isPrefix
+}
+
+// Discard skips the next n bytes, returning the number of bytes actually
+// discarded, without returning an error.
+func (b *Reader) Discard(n int) (discarded int) {
+	if b.err != nil {
+		return 0
+	}
+	discarded, b.err = b.br.Discard(n)
+	return discarded
+}
+
+// Peek returns the next n bytes without advancing the reader, without
+// returning an error. The returned slice is only valid until the next call
+// that reads from the Reader.
+func (b *Reader) Peek(n int) []byte {
+	if b.err != nil {
+		return nil
+	}
+	var p []byte
+	p, b.err = b.br.Peek(n)
+	return p
+}
+
+// readFull reads exactly n bytes into the shared scratch buffer, which
+// avoids allocating for the fixed-size binary reads below.
+func (b *Reader) readFull(n int) []byte {
+	t := b.tmp[:n]
+	_, b.err = io.ReadFull(b.br, t)
+	return t
+}
+
+// ReadUint16LE reads a value in binary with Little Endian order.
+func (b *Reader) ReadUint16LE() uint16 {
+	if b.err != nil {
+		return 0
+	}
+	t := b.readFull(2)
+	if b.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(t)
+}
+
+// ReadUint32LE reads a value in binary with Little Endian order.
+func (b *Reader) ReadUint32LE() uint32 {
+	if b.err != nil {
+		return 0
+	}
+	t := b.readFull(4)
+	if b.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(t)
+}
+
+// ReadUint64LE reads a value in binary with Little Endian order.
+func (b *Reader) ReadUint64LE() uint64 {
+	if b.err != nil {
+		return 0
+	}
+	t := b.readFull(8)
+	if b.err != nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(t)
+}
+
+// ReadUint16BE reads a value in binary with Big Endian order.
+func (b *Reader) ReadUint16BE() uint16 {
+	if b.err != nil {
+		return 0
+	}
+	t := b.readFull(2)
+	if b.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(t)
+}
+
+// ReadUint32BE reads a value in binary with Big Endian order.
+func (b *Reader) ReadUint32BE() uint32 {
+	if b.err != nil {
+		return 0
+	}
+	t := b.readFull(4)
+	if b.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(t)
+}
+
+// ReadUint64BE reads a value in binary with Big Endian order.
+func (b *Reader) ReadUint64BE() uint64 {
+	if b.err != nil {
+		return 0
+	}
+	t := b.readFull(8)
+	if b.err != nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(t)
+}
+
+// ReadInt16LE reads a value in binary with Little Endian order.
+func (b *Reader) ReadInt16LE() int16 {
+	return int16(b.ReadUint16LE())
+}
+
+// ReadInt32LE reads a value in binary with Little Endian order.
+func (b *Reader) ReadInt32LE() int32 {
+	return int32(b.ReadUint32LE())
+}
+
+// ReadInt64LE reads a value in binary with Little Endian order.
+func (b *Reader) ReadInt64LE() int64 {
+	return int64(b.ReadUint64LE())
+}
+
+// ReadInt16BE reads a value in binary with Big Endian order.
+func (b *Reader) ReadInt16BE() int16 {
+	return int16(b.ReadUint16BE())
+}
+
+// ReadInt32BE reads a value in binary with Big Endian order.
+func (b *Reader) ReadInt32BE() int32 {
+	return int32(b.ReadUint32BE())
+}
+
+// ReadInt64BE reads a value in binary with Big Endian order.
+func (b *Reader) ReadInt64BE() int64 {
+	return int64(b.ReadUint64BE())
+}
+
+// ReadFloat32LE reads an IEEE 754 value with Little Endian byte order.
+func (b *Reader) ReadFloat32LE() float32 {
+	return math.Float32frombits(b.ReadUint32LE())
+}
+
+// ReadFloat32BE reads an IEEE 754 value with Big Endian byte order.
+func (b *Reader) ReadFloat32BE() float32 {
+	return math.Float32frombits(b.ReadUint32BE())
+}
+
+// ReadFloat64LE reads an IEEE 754 value with Little Endian byte order.
+func (b *Reader) ReadFloat64LE() float64 {
+	return math.Float64frombits(b.ReadUint64LE())
+}
+
+// ReadFloat64BE reads an IEEE 754 value with Big Endian byte order.
+func (b *Reader) ReadFloat64BE() float64 {
+	return math.Float64frombits(b.ReadUint64BE())
+}
+
+// ReadUvarint reads a protobuf-style variable-length unsigned integer,
+// without returning an error.
+func (b *Reader) ReadUvarint() uint64 {
+	if b.err != nil {
+		return 0
+	}
+	var v uint64
+	v, b.err = binary.ReadUvarint(b.br)
+	return v
+}
+
+// ReadVarint reads a protobuf-style zig-zag encoded variable-length signed
+// integer, without returning an error.
+func (b *Reader) ReadVarint() int64 {
+	if b.err != nil {
+		return 0
+	}
+	var v int64
+	v, b.err = binary.ReadVarint(b.br)
+	return v
+}
@@ -0,0 +1,254 @@
+package easyreader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNewReader(t *testing.T) {
+	r := New(bytes.NewReader([]byte("foo")))
+	if s := r.ReadString(0); s != "foo" {
+		t.Fatal("unexpected string", s)
+	}
+	if !r.AtEOF() {
+		t.Fatal("expected EOF, got", r.Err())
+	}
+}
+
+func TestNewReaderSize(t *testing.T) {
+	r := NewSize(bytes.NewReader([]byte("foo")), 123)
+	if r.Size() != 123 {
+		t.Fatal("size not used", r.Size())
+	}
+}
+
+func TestNewReaderBufIO(t *testing.T) {
+	br := bufio.NewReaderSize(bytes.NewReader([]byte("foo")), 42)
+	r := FromBufIOReader(br)
+	if r.Size() != 42 {
+		t.Fatal("size not used", r.Size())
+	}
+}
+
+func TestReader_Text(t *testing.T) {
+	r := New(strings.NewReader("Hello, world\nsecond\nno newline"))
+
+	line1 := r.ReadString('\n')
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if line1 != "Hello, world\n" {
+		t.Fatal("unexpected line:", line1)
+	}
+
+	line2, isPrefix := r.ReadLine()
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if isPrefix || string(line2) != "second" {
+		t.Fatal("unexpected line:", string(line2))
+	}
+
+	rest := r.ReadFull(10)
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if string(rest) != "no newline" {
+		t.Fatal("unexpected rest:", string(rest))
+	}
+}
+
+func TestReader_ByteAndRune(t *testing.T) {
+	r := New(bytes.NewReader([]byte("A\U0001F600")))
+	if c := r.ReadByte(); c != 'A' {
+		t.Fatal("unexpected byte:", c)
+	}
+	ru, size := r.ReadRune()
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if ru != 0x1F600 || size != 4 {
+		t.Fatal("unexpected rune:", ru, size)
+	}
+}
+
+func TestReader_PeekAndDiscard(t *testing.T) {
+	r := New(bytes.NewReader([]byte("Hello")))
+	p := r.Peek(3)
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if string(p) != "Hel" {
+		t.Fatal("unexpected peek:", string(p))
+	}
+	n := r.Discard(2)
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatal("unexpected discard count:", n)
+	}
+	rest := r.ReadFull(3)
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if string(rest) != "llo" {
+		t.Fatal("unexpected rest:", string(rest))
+	}
+}
+
+func TestReader_Binary(t *testing.T) {
+	buf := new(bytes.Buffer)
+	_ = binary.Write(buf, binary.LittleEndian, uint16(1))
+	_ = binary.Write(buf, binary.LittleEndian, uint32(2))
+	_ = binary.Write(buf, binary.LittleEndian, uint64(3))
+	_ = binary.Write(buf, binary.BigEndian, uint16(4))
+	_ = binary.Write(buf, binary.BigEndian, uint32(5))
+	_ = binary.Write(buf, binary.BigEndian, uint64(6))
+	_ = binary.Write(buf, binary.LittleEndian, int16(-1))
+	_ = binary.Write(buf, binary.LittleEndian, int32(-2))
+	_ = binary.Write(buf, binary.LittleEndian, int64(-3))
+	_ = binary.Write(buf, binary.BigEndian, int16(-1))
+	_ = binary.Write(buf, binary.BigEndian, int32(-2))
+	_ = binary.Write(buf, binary.BigEndian, int64(-3))
+	_ = binary.Write(buf, binary.LittleEndian, math.Float32bits(1.5))
+	_ = binary.Write(buf, binary.BigEndian, math.Float32bits(1.5))
+	_ = binary.Write(buf, binary.LittleEndian, math.Float64bits(2.5))
+	_ = binary.Write(buf, binary.BigEndian, math.Float64bits(2.5))
+
+	r := New(buf)
+	if v := r.ReadUint16LE(); v != 1 {
+		t.Fatal(v)
+	}
+	if v := r.ReadUint32LE(); v != 2 {
+		t.Fatal(v)
+	}
+	if v := r.ReadUint64LE(); v != 3 {
+		t.Fatal(v)
+	}
+	if v := r.ReadUint16BE(); v != 4 {
+		t.Fatal(v)
+	}
+	if v := r.ReadUint32BE(); v != 5 {
+		t.Fatal(v)
+	}
+	if v := r.ReadUint64BE(); v != 6 {
+		t.Fatal(v)
+	}
+	if v := r.ReadInt16LE(); v != -1 {
+		t.Fatal(v)
+	}
+	if v := r.ReadInt32LE(); v != -2 {
+		t.Fatal(v)
+	}
+	if v := r.ReadInt64LE(); v != -3 {
+		t.Fatal(v)
+	}
+	if v := r.ReadInt16BE(); v != -1 {
+		t.Fatal(v)
+	}
+	if v := r.ReadInt32BE(); v != -2 {
+		t.Fatal(v)
+	}
+	if v := r.ReadInt64BE(); v != -3 {
+		t.Fatal(v)
+	}
+	if v := r.ReadFloat32LE(); v != 1.5 {
+		t.Fatal(v)
+	}
+	if v := r.ReadFloat32BE(); v != 1.5 {
+		t.Fatal(v)
+	}
+	if v := r.ReadFloat64LE(); v != 2.5 {
+		t.Fatal(v)
+	}
+	if v := r.ReadFloat64BE(); v != 2.5 {
+		t.Fatal(v)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+}
+
+func TestReader_Varint(t *testing.T) {
+	buf := make([]byte, 0, 32)
+	buf = binary.AppendUvarint(buf, 300)
+	buf = binary.AppendVarint(buf, -300)
+
+	r := New(bytes.NewReader(buf))
+	if v := r.ReadUvarint(); v != 300 {
+		t.Fatal(v)
+	}
+	if v := r.ReadVarint(); v != -300 {
+		t.Fatal(v)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+}
+
+func TestReader_EOF(t *testing.T) {
+	r := New(bytes.NewReader(nil))
+	v := r.ReadUint32BE()
+	if v != 0 {
+		t.Fatal("expected 0, got", v)
+	}
+	if !r.AtEOF() {
+		t.Fatal("expected io.EOF, got", r.Err())
+	}
+}
+
+func TestReader_UnexpectedEOF(t *testing.T) {
+	r := New(bytes.NewReader([]byte{1, 2}))
+	v := r.ReadUint32BE()
+	if v != 0 {
+		t.Fatal("expected 0, got", v)
+	}
+	if r.AtEOF() {
+		t.Fatal("should not report AtEOF for a partial read")
+	}
+	if !errors.Is(r.Err(), io.ErrUnexpectedEOF) {
+		t.Fatal("expected io.ErrUnexpectedEOF, got", r.Err())
+	}
+}
+
+func TestReader_Error(t *testing.T) {
+	r := New(ioutil.NopCloser(bytes.NewReader(nil)))
+	r.ReadByte()
+	if !r.AtEOF() {
+		t.Fatal("expected EOF")
+	}
+	r.ResetErr()
+	if err := r.Err(); err != nil {
+		t.Fatal("Err not reset, got:", err)
+	}
+}
+
+func BenchmarkReader_ReadByte(b *testing.B) {
+	data := bytes.Repeat([]byte{'x'}, b.N)
+	r := New(bytes.NewReader(data))
+	b.ReportAllocs()
+	b.SetBytes(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ReadByte()
+	}
+}
+
+func BenchmarkReader_ReadUint32BE(b *testing.B) {
+	data := make([]byte, b.N*4)
+	r := New(bytes.NewReader(data))
+	b.ReportAllocs()
+	b.SetBytes(4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.ReadUint32BE()
+	}
+}
@@ -0,0 +1,168 @@
+package easywriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+)
+
+// FramePrefix selects the on-disk representation of a Frame's length prefix.
+type FramePrefix int
+
+const (
+	FrameUint16LE FramePrefix = iota
+	FrameUint16BE
+	FrameUint32LE
+	FrameUint32BE
+	FrameUint64LE
+	FrameUint64BE
+	FrameUvarint
+)
+
+// size returns the number of bytes the prefix takes up, or 0 for
+// FrameUvarint, whose length is not known ahead of time.
+func (p FramePrefix) size() int {
+	switch p {
+	case FrameUint16LE, FrameUint16BE:
+		return 2
+	case FrameUint32LE, FrameUint32BE:
+		return 4
+	case FrameUint64LE, FrameUint64BE:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// putLength encodes n directly into a slot previously obtained from Reserve.
+func (p FramePrefix) putLength(slot []byte, n int64) {
+	switch p {
+	case FrameUint16LE:
+		binary.LittleEndian.PutUint16(slot, uint16(n))
+	case FrameUint16BE:
+		binary.BigEndian.PutUint16(slot, uint16(n))
+	case FrameUint32LE:
+		binary.LittleEndian.PutUint32(slot, uint32(n))
+	case FrameUint32BE:
+		binary.BigEndian.PutUint32(slot, uint32(n))
+	case FrameUint64LE:
+		binary.LittleEndian.PutUint64(slot, uint64(n))
+	case FrameUint64BE:
+		binary.BigEndian.PutUint64(slot, uint64(n))
+	}
+}
+
+// writeTo writes n as a length prefix through w's normal Write* methods,
+// used for the buffered fallback path where there is no reserved slot left
+// to patch in place.
+func (p FramePrefix) writeTo(w *Writer, n int64) {
+	switch p {
+	case FrameUint16LE:
+		w.WriteUint16LE(uint16(n))
+	case FrameUint16BE:
+		w.WriteUint16BE(uint16(n))
+	case FrameUint32LE:
+		w.WriteUint32LE(uint32(n))
+	case FrameUint32BE:
+		w.WriteUint32BE(uint32(n))
+	case FrameUint64LE:
+		w.WriteUint64LE(uint64(n))
+	case FrameUint64BE:
+		w.WriteUint64BE(uint64(n))
+	case FrameUvarint:
+		w.WriteUvarint(uint64(n))
+	}
+}
+
+var framePool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// Frame represents a length-delimited record being written through a Writer.
+// Use BeginFrame to start one; Frame embeds a *Writer, so all of the normal
+// Write* methods are available directly on it. Call End once the payload has
+// been fully written to backfill the length prefix. Frames may be nested by
+// calling BeginFrame again on a Frame.
+//
+// Hazard: a reserved-in-place prefix (see BeginFrame) lives inside the
+// bufio.Writer's buffer until it is flushed. Writing a body large enough to
+// overflow the remaining buffer space makes bufio.Writer flush on its own,
+// sending the prefix out with its placeholder zero value before End ever
+// gets to patch it. Writer detects this (Reserve tracks bytes that have
+// actually reached the real destination, not just explicit Flush calls) and
+// End's call to patch sets Err to errPatchTooLate rather than leaving a
+// corrupted length prefix unreported, but the already-written bytes cannot
+// be recalled. Check Err after End if that distinction matters to the
+// caller.
+type Frame struct {
+	*Writer
+	outer  *Writer
+	prefix FramePrefix
+	start  int64
+	slot   []byte
+	patch  func()
+	body   *bytes.Buffer // non-nil when the prefix could not be reserved in place
+}
+
+// BeginFrame starts a new length-prefixed frame using the given prefix
+// format. It tries to reserve the prefix directly in the buffer so the
+// payload can be written straight through without an extra copy; if that is
+// not possible (not enough room, or the format is FrameUvarint, whose size
+// isn't known until the payload is), it buffers the frame body in a pooled
+// bytes.Buffer instead and assembles prefix+body on End.
+func (b *Writer) BeginFrame(prefix FramePrefix) *Frame {
+	f := &Frame{outer: b, prefix: prefix}
+	if b.err != nil {
+		f.Writer = b
+		return f
+	}
+	if prefix != FrameUvarint {
+		if slot, patch := b.Reserve(prefix.size()); b.err == nil {
+			f.slot = slot
+			f.patch = patch
+			f.start = b.pos
+			f.Writer = b
+			return f
+		}
+		if !errors.Is(b.err, errReserveTooLarge) {
+			// A real destination error, not Reserve merely declining because
+			// the prefix doesn't fit; propagate it instead of falling back
+			// to buffering as if nothing happened.
+			f.Writer = b
+			return f
+		}
+		b.err = nil // Reserve declining just means falling back to buffering
+	}
+	body := framePool.Get().(*bytes.Buffer)
+	body.Reset()
+	f.body = body
+	f.Writer = New(body)
+	f.Writer.WithByteOrder(b.byteOrder)
+	return f
+}
+
+// End finalizes the frame, writing the accumulated byte count into the
+// reserved prefix, or flushing the buffered body together with its prefix.
+// Errors are set on the underlying Writer in the usual delayed way.
+func (f *Frame) End() {
+	if f.body != nil {
+		defer framePool.Put(f.body)
+		if f.outer.err != nil {
+			return
+		}
+		f.Writer.FlushInterim()
+		if f.Writer.Err() != nil {
+			f.outer.err = f.Writer.Err()
+			return
+		}
+		n := int64(f.body.Len())
+		f.prefix.writeTo(f.outer, n)
+		f.outer.WriteBytes(f.body.Bytes())
+		return
+	}
+	if f.outer.err != nil {
+		return
+	}
+	n := f.outer.pos - f.start
+	f.prefix.putLength(f.slot, n)
+	f.patch()
+}
@@ -0,0 +1,151 @@
+package easywriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestWriter_Frame_Uint32BE_ReservedInPlace(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf)
+
+	f := w.BeginFrame(FrameUint32BE)
+	f.WriteString("hello")
+	f.End()
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0, 0, 0, 5}
+	want = append(want, "hello"...)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+// TestWriter_Frame_Uint32BE_DetectsImplicitFlush covers the case where the
+// frame body is large enough that bufio.Writer flushes the reserved prefix
+// on its own, mid-Write, without any explicit Flush/FlushInterim call. The
+// prefix has already gone out with its placeholder zero value by the time
+// End tries to patch it, so patch must report errPatchTooLate instead of
+// leaving Err nil over a silently corrupted prefix.
+func TestWriter_Frame_Uint32BE_DetectsImplicitFlush(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 16) // far smaller than the body, forcing bufio to flush on its own
+
+	f := w.BeginFrame(FrameUint32BE)
+	f.WriteBytes(bytes.Repeat([]byte{'x'}, 100))
+	f.End()
+
+	if !errors.Is(w.Err(), errPatchTooLate) {
+		t.Fatalf("expected errPatchTooLate, got %v", w.Err())
+	}
+}
+
+// erroringWriter fails every Write, used to simulate a genuine destination
+// I/O error surfacing through Reserve's internal FlushInterim.
+type erroringWriter struct{}
+
+func (erroringWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("erroringWriter: write failed")
+}
+
+func TestWriter_Frame_PropagatesRealReserveError(t *testing.T) {
+	w := NewSize(erroringWriter{}, 4) // exactly the size of an FrameUint32BE prefix
+
+	w.WriteByte('a') // leaves only 3 bytes available, so Reserve's FlushInterim runs
+	f := w.BeginFrame(FrameUint32BE)
+	f.WriteString("payload")
+	f.End()
+
+	if w.Err() == nil {
+		t.Fatal("expected the destination write error to be propagated, got nil")
+	}
+}
+
+func TestWriter_Frame_Uvarint_Buffered(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf)
+
+	f := w.BeginFrame(FrameUvarint)
+	f.WriteString("hello world")
+	f.End()
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := binary.AppendUvarint(nil, 11)
+	want = append(want, "hello world"...)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Frame_Uvarint_Buffered_UsesOuterByteOrder(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf).WithByteOrder(binary.BigEndian)
+
+	f := w.BeginFrame(FrameUvarint)
+	f.WriteUint16(0x1234)
+	f.End()
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := binary.AppendUvarint(nil, 2)
+	want = append(want, 0x12, 0x34)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Frame_FallsBackWhenPrefixDoesNotFit(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 4) // smaller than an 8 byte prefix, so Reserve always fails
+
+	f := w.BeginFrame(FrameUint64BE)
+	f.WriteString("payload")
+	f.End()
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0, 0, 0, 0, 0, 0, 0, 7}
+	want = append(want, "payload"...)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Frame_Nested(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf)
+
+	outer := w.BeginFrame(FrameUint16BE)
+	outer.WriteString("A")
+	inner := outer.BeginFrame(FrameUint16BE)
+	inner.WriteString("BB")
+	inner.End()
+	outer.WriteString("C")
+	outer.End()
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0, 6, 'A', 0, 2, 'B', 'B', 'C'}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
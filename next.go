@@ -0,0 +1,162 @@
+package easywriter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"unsafe"
+)
+
+// bufioWriterBuf reaches into bw's unexported buffer and write offset.
+// bufio.Writer does not expose a way to hand out a slice of its internal
+// buffer, so Next and Reserve need this to avoid the extra copy they exist
+// to eliminate. It relies on bufio.Writer having fields named "buf" and "n",
+// which has been stable since Go 1.0.
+func bufioWriterBuf(bw *bufio.Writer) (buf []byte, pos int) {
+	rv := reflect.ValueOf(bw).Elem()
+	bufField := rv.FieldByName("buf")
+	nField := rv.FieldByName("n")
+	buf = *(*[]byte)(unsafe.Pointer(bufField.UnsafeAddr()))
+	pos = int(nField.Int())
+	return buf, pos
+}
+
+// bufioWriterSetPos advances bw's unexported write offset, claiming the
+// bytes between the old and new position as already written without going
+// through Write.
+func bufioWriterSetPos(bw *bufio.Writer, pos int) {
+	rv := reflect.ValueOf(bw).Elem()
+	nField := rv.FieldByName("n")
+	*(*int)(unsafe.Pointer(nField.UnsafeAddr())) = pos
+}
+
+// bufioWriterWrapDest replaces bw's unexported destination writer ("wr")
+// with wrap(existing destination). It relies on bufio.Writer having a field
+// named "wr", stable since Go 1.0, and lets FromBufIOWriter interpose a
+// counter even though the bufio.Writer it is handed already wraps its real
+// destination.
+func bufioWriterWrapDest(bw *bufio.Writer, wrap func(io.Writer) io.Writer) {
+	rv := reflect.ValueOf(bw).Elem()
+	wrField := rv.FieldByName("wr")
+	ptr := (*io.Writer)(unsafe.Pointer(wrField.UnsafeAddr()))
+	*ptr = wrap(*ptr)
+}
+
+// flushCountingWriter sits between a Writer's bufio.Writer and its real
+// destination, counting every byte that actually leaves the buffer. Reserve
+// uses this (via Writer.flushedPos) to tell whether a reserved slot is still
+// patchable in place, instead of only tracking explicit Flush/FlushInterim
+// calls, which miss flushes bufio.Writer triggers internally.
+type flushCountingWriter struct {
+	dest io.Writer
+	w    *Writer
+}
+
+func (c *flushCountingWriter) Write(p []byte) (int, error) {
+	n, err := c.dest.Write(p)
+	c.w.flushedPos += int64(n)
+	return n, err
+}
+
+// ensureFlushTracking interposes flushCountingWriter between bw and its real
+// destination the first time Next or Reserve is called on b. This is only
+// needed to support Reserve's patch-safety check, so it stays out of
+// FromBufIOWriter: wrapping unconditionally would replace bw's destination
+// for every Writer, including ones that never call Reserve/Next, silently
+// defeating bufio.Writer's ReadFrom/WriteString fast paths (io.ReaderFrom
+// and io.StringWriter type assertions on the destination) for unrelated
+// callers.
+func (b *Writer) ensureFlushTracking() {
+	if b.flushWrapped {
+		return
+	}
+	b.flushWrapped = true
+	// Bytes accepted so far that are no longer buffered have already reached
+	// the destination, so start counting from there rather than from zero.
+	b.flushedPos = b.pos - int64(b.bw.Buffered())
+	bufioWriterWrapDest(b.bw, func(dest io.Writer) io.Writer {
+		return &flushCountingWriter{dest: dest, w: b}
+	})
+}
+
+// Next returns a slice of exactly n bytes pointing directly into the
+// underlying bufio buffer, for callers that want to populate a fixed-size
+// record in place instead of writing it to a temporary slice first. It
+// flushes first if n does not currently fit in the available space.
+//
+// If n is larger than Size(), there is no buffer space that could ever fit
+// it, so Next falls back to a freshly allocated slice. That slice is not
+// connected to the Writer in any way; the caller must still hand it to
+// WriteBytes once it has been populated.
+func (b *Writer) Next(n int) []byte {
+	if b.err != nil {
+		return nil
+	}
+	if n > b.Size() {
+		return make([]byte, n)
+	}
+	b.ensureFlushTracking()
+	if n > b.Available() {
+		b.FlushInterim()
+		if b.err != nil {
+			return nil
+		}
+	}
+	buf, pos := bufioWriterBuf(b.bw)
+	bufioWriterSetPos(b.bw, pos+n)
+	b.pos += int64(n)
+	return buf[pos : pos+n : pos+n]
+}
+
+// errReserveTooLarge is returned via Err when Reserve is asked for more
+// bytes than the Writer's buffer can ever hold.
+var errReserveTooLarge = errors.New("easywriter: Reserve(n) exceeds buffer size")
+
+// errPatchTooLate is set via Err when a Reserve patch function is called
+// after the reserved region has already been flushed to the underlying
+// io.Writer, so patching it in place is no longer possible.
+var errPatchTooLate = errors.New("easywriter: Reserve slot was flushed before it could be patched")
+
+// Reserve sets aside n bytes in the buffer and returns them as slot, along
+// with a patch function. The caller can write into slot directly, for
+// example to backfill a length prefix once the payload size is known; patch
+// must then be called to confirm the write is in place, which also detects
+// the case where the reserved region has already reached the real
+// destination in the meantime (e.g. because a later write didn't fit the
+// remaining buffer space, triggering one of bufio.Writer's own internal
+// flushes, not just an explicit Flush/FlushInterim call), in which case slot
+// can no longer be patched and patch sets Err to errPatchTooLate instead.
+//
+// Reserve requires n to fit within Size(); if it does not, Err is set to
+// errReserveTooLarge and a nil slot and a no-op patch are returned.
+func (b *Writer) Reserve(n int) (slot []byte, patch func()) {
+	noop := func() {}
+	if b.err != nil {
+		return nil, noop
+	}
+	if n > b.Size() {
+		b.err = fmt.Errorf("%w: n=%d size=%d", errReserveTooLarge, n, b.Size())
+		return nil, noop
+	}
+	b.ensureFlushTracking()
+	if n > b.Available() {
+		b.FlushInterim()
+		if b.err != nil {
+			return nil, noop
+		}
+	}
+	buf, pos := bufioWriterBuf(b.bw)
+	bufioWriterSetPos(b.bw, pos+n)
+	sliceStart := b.pos
+	b.pos += int64(n)
+
+	slot = buf[pos : pos+n : pos+n]
+	patch = func() {
+		if b.flushedPos > sliceStart && b.err == nil {
+			b.err = errPatchTooLate
+		}
+	}
+	return slot, patch
+}
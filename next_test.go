@@ -0,0 +1,159 @@
+package easywriter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// readerFromSpy implements io.ReaderFrom so its use can be observed; it
+// stands in for the destination's io.ReaderFrom fast path that
+// bufio.Writer.ReadFrom only takes if it can type-assert its destination
+// writer directly, i.e. only if nothing has wrapped it in between.
+type readerFromSpy struct {
+	bytes.Buffer
+	used bool
+}
+
+func (s *readerFromSpy) ReadFrom(r io.Reader) (int64, error) {
+	s.used = true
+	return s.Buffer.ReadFrom(r)
+}
+
+func TestWriter_ReadFrom_FastPath_PreservedWithoutReserve(t *testing.T) {
+	dst := &readerFromSpy{}
+	w := New(dst)
+
+	w.ReadBytesFrom(bytes.NewReader([]byte("hello")))
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.used {
+		t.Fatal("expected bufio.Writer's io.ReaderFrom fast path to be used, but it was bypassed")
+	}
+}
+
+func TestWriter_Next(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 16)
+
+	slot := w.Next(4)
+	if len(slot) != 4 {
+		t.Fatalf("expected 4 byte slot, got %d", len(slot))
+	}
+	copy(slot, []byte{1, 2, 3, 4})
+	w.WriteByte('x')
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{1, 2, 3, 4, 'x'}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Next_Flushes_When_Full(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 4)
+
+	w.WriteByte('a')
+	w.WriteByte('b')
+	slot := w.Next(4)
+	if len(slot) != 4 {
+		t.Fatalf("expected 4 byte slot, got %d", len(slot))
+	}
+	copy(slot, []byte{1, 2, 3, 4})
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{'a', 'b', 1, 2, 3, 4}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Next_LargerThanBuffer(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 4)
+
+	slot := w.Next(10)
+	if len(slot) != 10 {
+		t.Fatalf("expected 10 byte fallback slot, got %d", len(slot))
+	}
+	for i := range slot {
+		slot[i] = byte(i)
+	}
+	w.WriteBytes(slot)
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), slot) {
+		t.Fatalf("expected %v, got %v", slot, buf.Bytes())
+	}
+}
+
+func TestWriter_Reserve_PatchInPlace(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 32)
+
+	slot, patch := w.Reserve(4)
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+	w.WriteString("payload")
+	copy(slot, []byte{0, 0, 0, 7})
+	patch()
+	if err := w.Err(); err != nil {
+		t.Fatal("patch should still succeed before flush:", err)
+	}
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := append([]byte{0, 0, 0, 7}, "payload"...)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("expected %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Reserve_TooLateToPatch(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewSize(buf, 8)
+
+	slot, patch := w.Reserve(4)
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+	w.WriteString("abcd")
+	w.FlushInterim() // pushes the reserved slot out to buf before it is patched
+	if err := w.Err(); err != nil {
+		t.Fatal(err)
+	}
+	copy(slot, []byte{1, 2, 3, 4})
+	patch()
+	if err := w.Err(); err != errPatchTooLate {
+		t.Fatal("expected errPatchTooLate, got", w.Err())
+	}
+}
+
+func TestWriter_Reserve_ExceedsBufferSize(t *testing.T) {
+	w := NewSize(bytes.NewBuffer(nil), 4)
+	slot, patch := w.Reserve(8)
+	if slot != nil {
+		t.Fatal("expected nil slot")
+	}
+	patch()
+	if w.Err() == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -10,20 +10,36 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 )
 
 const (
 	defaultBufSize = 4096
+
+	// MaxVarintLen64 is the maximum length in bytes of a varint-encoded uint64.
+	MaxVarintLen64 = 10
 )
 
 // Writer is an io.Writer with many convenience methods that allow delayed error
 // checking.
 type Writer struct {
-	bw      *bufio.Writer
-	err     error
-	tmp     []byte
-	tmpdata [64]byte // prevents heap alloc, fits 64 bit number formatted as binary
+	bw           *bufio.Writer
+	err          error
+	tmp          []byte
+	tmpdata      [64]byte // prevents heap alloc, fits 64 bit number formatted as binary
+	byteOrder    binary.ByteOrder
+	pos          int64 // total bytes ever accepted (buffered or already flushed)
+	flushedPos   int64 // total bytes that have actually reached the real destination, used by Reserve
+	flushWrapped bool  // set once bufioWriterWrapDest has interposed flushCountingWriter
+}
+
+// Pos returns the total number of bytes ever accepted by the Writer, whether
+// still buffered or already flushed. It is used by Frame to compute frame
+// lengths and does not reflect how many bytes have actually reached the
+// underlying io.Writer.
+func (b *Writer) Pos() int64 {
+	return b.pos
 }
 
 // New constructs a Writer from an io.Writer. It wraps it in a bufio.Writer,
@@ -45,11 +61,21 @@ func NewSize(w io.Writer, size int) *Writer {
 
 // New constructs a Writer from a bufio.Writer.
 func FromBufIOWriter(bw *bufio.Writer) *Writer {
-	w := Writer{
-		bw: bw,
+	w := &Writer{
+		bw:        bw,
+		byteOrder: binary.LittleEndian,
 	}
 	w.tmp = w.tmpdata[:]
-	return &w
+	return w
+}
+
+// WithByteOrder sets the byte order used by the endianness-agnostic
+// WriteUint16/32/64 and WriteInt16/32/64 methods. The default is Little
+// Endian. It returns the Writer so it can be chained onto New/NewSize/
+// FromBufIOWriter.
+func (b *Writer) WithByteOrder(order binary.ByteOrder) *Writer {
+	b.byteOrder = order
+	return b
 }
 
 // Err returns the current error, if any. Reading the error does not reset it.
@@ -106,6 +132,7 @@ func (b *Writer) ReadFrom(r io.Reader) (n int64, err error) {
 		return 0, b.err
 	}
 	n, b.err = b.bw.ReadFrom(r)
+	b.pos += n
 	return n, b.err
 }
 
@@ -115,6 +142,7 @@ func (b *Writer) ReadBytesFrom(r io.Reader) (n int64) {
 		return 0
 	}
 	n, b.err = b.bw.ReadFrom(r)
+	b.pos += n
 	return n
 }
 
@@ -125,6 +153,7 @@ func (b *Writer) Write(p []byte) (nn int, err error) {
 		return 0, b.err
 	}
 	nn, b.err = b.bw.Write(p)
+	b.pos += int64(nn)
 	return nn, b.err
 }
 
@@ -134,6 +163,7 @@ func (b *Writer) WriteBytes(p []byte) (nn int) {
 		return 0
 	}
 	nn, b.err = b.bw.Write(p)
+	b.pos += int64(nn)
 	return nn
 }
 
@@ -143,6 +173,9 @@ func (b *Writer) WriteByte(c byte) {
 		return
 	}
 	b.err = b.bw.WriteByte(c)
+	if b.err == nil {
+		b.pos++
+	}
 }
 
 // WriteByte writes a single rune without returning an error.
@@ -151,6 +184,7 @@ func (b *Writer) WriteRune(r rune) (size int) {
 		return
 	}
 	size, b.err = b.bw.WriteRune(r)
+	b.pos += int64(size)
 	return size
 }
 
@@ -160,6 +194,7 @@ func (b *Writer) WriteString(s string) (n int) {
 		return 0
 	}
 	n, b.err = b.bw.WriteString(s)
+	b.pos += int64(n)
 	return n
 }
 
@@ -192,6 +227,7 @@ func (b *Writer) WriteNumber64(num int64, base int) (n int) {
 	t := b.tmp[:0]
 	t = strconv.AppendInt(t, num, base)
 	n, b.err = b.bw.Write(t)
+	b.pos += int64(n)
 	return n
 }
 
@@ -204,6 +240,7 @@ func (b *Writer) WriteUnsignedNumber64(num uint64, base int) (n int) {
 	t := b.tmp[:0]
 	t = strconv.AppendUint(t, num, base)
 	n, b.err = b.bw.Write(t)
+	b.pos += int64(n)
 	return n
 }
 
@@ -213,6 +250,7 @@ func (b *Writer) Printf(format string, a ...interface{}) (n int) {
 		return 0
 	}
 	n, b.err = fmt.Fprintf(b.bw, format, a...)
+	b.pos += int64(n)
 	return n
 }
 
@@ -222,6 +260,7 @@ func (b *Writer) Println(a ...interface{}) (n int) {
 		return 0
 	}
 	n, b.err = fmt.Fprintln(b.bw, a...)
+	b.pos += int64(n)
 	return n
 }
 
@@ -231,6 +270,7 @@ func (b *Writer) Print(a ...interface{}) (n int) {
 		return 0
 	}
 	n, b.err = fmt.Fprint(b.bw, a...)
+	b.pos += int64(n)
 	return n
 }
 
@@ -242,6 +282,9 @@ func (b *Writer) WriteUint16LE(v uint16) {
 	t := b.tmp[:2]
 	binary.LittleEndian.PutUint16(t, v)
 	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 2
+	}
 }
 
 // WriteUint32LE writes given value in binary with Little Endian order.
@@ -252,6 +295,9 @@ func (b *Writer) WriteUint32LE(v uint32) {
 	t := b.tmp[:4]
 	binary.LittleEndian.PutUint32(t, v)
 	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 4
+	}
 }
 
 // WriteUint64LE writes given value in binary with Little Endian order.
@@ -262,6 +308,9 @@ func (b *Writer) WriteUint64LE(v uint64) {
 	t := b.tmp[:8]
 	binary.LittleEndian.PutUint64(t, v)
 	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 8
+	}
 }
 
 // WriteUint16BE writes given value in binary with Big Endian order.
@@ -272,6 +321,9 @@ func (b *Writer) WriteUint16BE(v uint16) {
 	t := b.tmp[:2]
 	binary.BigEndian.PutUint16(t, v)
 	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 2
+	}
 }
 
 // WriteUint32BE writes given value in binary with Big Endian order.
@@ -282,6 +334,9 @@ func (b *Writer) WriteUint32BE(v uint32) {
 	t := b.tmp[:4]
 	binary.BigEndian.PutUint32(t, v)
 	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 4
+	}
 }
 
 // WriteUint64BE writes given value in binary with Big Endian order.
@@ -292,4 +347,182 @@ func (b *Writer) WriteUint64BE(v uint64) {
 	t := b.tmp[:8]
 	binary.BigEndian.PutUint64(t, v)
 	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 8
+	}
+}
+
+// WriteUint8 writes given value as a single byte.
+func (b *Writer) WriteUint8(v uint8) {
+	if b.err != nil {
+		return
+	}
+	b.err = b.bw.WriteByte(v)
+	if b.err == nil {
+		b.pos++
+	}
+}
+
+// WriteInt8 writes given value as a single byte.
+func (b *Writer) WriteInt8(v int8) {
+	b.WriteUint8(uint8(v))
+}
+
+// WriteBoolByte writes v as a single byte, 1 for true and 0 for false.
+func (b *Writer) WriteBoolByte(v bool) {
+	if v {
+		b.WriteUint8(1)
+	} else {
+		b.WriteUint8(0)
+	}
+}
+
+// WriteInt16LE writes given value in binary with Little Endian order.
+func (b *Writer) WriteInt16LE(v int16) {
+	b.WriteUint16LE(uint16(v))
+}
+
+// WriteInt32LE writes given value in binary with Little Endian order.
+func (b *Writer) WriteInt32LE(v int32) {
+	b.WriteUint32LE(uint32(v))
+}
+
+// WriteInt64LE writes given value in binary with Little Endian order.
+func (b *Writer) WriteInt64LE(v int64) {
+	b.WriteUint64LE(uint64(v))
+}
+
+// WriteInt16BE writes given value in binary with Big Endian order.
+func (b *Writer) WriteInt16BE(v int16) {
+	b.WriteUint16BE(uint16(v))
+}
+
+// WriteInt32BE writes given value in binary with Big Endian order.
+func (b *Writer) WriteInt32BE(v int32) {
+	b.WriteUint32BE(uint32(v))
+}
+
+// WriteInt64BE writes given value in binary with Big Endian order.
+func (b *Writer) WriteInt64BE(v int64) {
+	b.WriteUint64BE(uint64(v))
+}
+
+// WriteFloat32LE writes given value in IEEE 754 binary representation with
+// Little Endian byte order.
+func (b *Writer) WriteFloat32LE(v float32) {
+	b.WriteUint32LE(math.Float32bits(v))
+}
+
+// WriteFloat32BE writes given value in IEEE 754 binary representation with
+// Big Endian byte order.
+func (b *Writer) WriteFloat32BE(v float32) {
+	b.WriteUint32BE(math.Float32bits(v))
+}
+
+// WriteFloat64LE writes given value in IEEE 754 binary representation with
+// Little Endian byte order.
+func (b *Writer) WriteFloat64LE(v float64) {
+	b.WriteUint64LE(math.Float64bits(v))
+}
+
+// WriteFloat64BE writes given value in IEEE 754 binary representation with
+// Big Endian byte order.
+func (b *Writer) WriteFloat64BE(v float64) {
+	b.WriteUint64BE(math.Float64bits(v))
+}
+
+// WriteUint16 writes given value in binary using the Writer's configured
+// byte order, see WithByteOrder.
+func (b *Writer) WriteUint16(v uint16) {
+	if b.err != nil {
+		return
+	}
+	t := b.tmp[:2]
+	b.byteOrder.PutUint16(t, v)
+	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 2
+	}
+}
+
+// WriteUint32 writes given value in binary using the Writer's configured
+// byte order, see WithByteOrder.
+func (b *Writer) WriteUint32(v uint32) {
+	if b.err != nil {
+		return
+	}
+	t := b.tmp[:4]
+	b.byteOrder.PutUint32(t, v)
+	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 4
+	}
+}
+
+// WriteUint64 writes given value in binary using the Writer's configured
+// byte order, see WithByteOrder.
+func (b *Writer) WriteUint64(v uint64) {
+	if b.err != nil {
+		return
+	}
+	t := b.tmp[:8]
+	b.byteOrder.PutUint64(t, v)
+	_, b.err = b.bw.Write(t)
+	if b.err == nil {
+		b.pos += 8
+	}
+}
+
+// WriteInt16 writes given value in binary using the Writer's configured
+// byte order, see WithByteOrder.
+func (b *Writer) WriteInt16(v int16) {
+	b.WriteUint16(uint16(v))
+}
+
+// WriteInt32 writes given value in binary using the Writer's configured
+// byte order, see WithByteOrder.
+func (b *Writer) WriteInt32(v int32) {
+	b.WriteUint32(uint32(v))
+}
+
+// WriteInt64 writes given value in binary using the Writer's configured
+// byte order, see WithByteOrder.
+func (b *Writer) WriteInt64(v int64) {
+	b.WriteUint64(uint64(v))
+}
+
+// WriteUvarint writes v as a protobuf-style variable-length unsigned integer,
+// 7 payload bits per byte with the continuation bit set on every byte but the
+// last. It uses at most MaxVarintLen64 bytes.
+func (b *Writer) WriteUvarint(v uint64) {
+	if b.err != nil {
+		return
+	}
+	t := b.tmp[:MaxVarintLen64]
+	n := binary.PutUvarint(t, v)
+	_, b.err = b.bw.Write(t[:n])
+	if b.err == nil {
+		b.pos += int64(n)
+	}
+}
+
+// WriteVarint writes v as a protobuf-style zig-zag encoded variable-length
+// signed integer.
+func (b *Writer) WriteVarint(v int64) {
+	if b.err != nil {
+		return
+	}
+	t := b.tmp[:MaxVarintLen64]
+	n := binary.PutVarint(t, v)
+	_, b.err = b.bw.Write(t[:n])
+	if b.err == nil {
+		b.pos += int64(n)
+	}
+}
+
+// WriteUvarintSlice writes each value in vs as a separate Uvarint.
+func (b *Writer) WriteUvarintSlice(vs []uint64) {
+	for _, v := range vs {
+		b.WriteUvarint(v)
+	}
 }
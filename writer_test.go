@@ -3,8 +3,10 @@ package easywriter
 import (
 	"bufio"
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"io/ioutil"
+	"math"
 	"strings"
 	"testing"
 )
@@ -201,6 +203,111 @@ func TestWriter_Write_Binary(t *testing.T) {
 	}
 }
 
+func TestWriter_Write_SignedAndFloat(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf)
+
+	w.WriteInt8(-1)
+	w.WriteUint8(254)
+	w.WriteBoolByte(true)
+	w.WriteBoolByte(false)
+	w.WriteInt16LE(-2)
+	w.WriteInt32LE(-3)
+	w.WriteInt64LE(-4)
+	w.WriteInt16BE(-2)
+	w.WriteInt32BE(-3)
+	w.WriteInt64BE(-4)
+	w.WriteFloat32LE(1.5)
+	w.WriteFloat32BE(1.5)
+	w.WriteFloat64LE(2.5)
+	w.WriteFloat64BE(2.5)
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	got := buf.Bytes()
+
+	wbuf := new(bytes.Buffer)
+	_ = binary.Write(wbuf, binary.BigEndian, int8(-1))
+	_ = binary.Write(wbuf, binary.BigEndian, uint8(254))
+	_ = binary.Write(wbuf, binary.BigEndian, uint8(1))
+	_ = binary.Write(wbuf, binary.BigEndian, uint8(0))
+	_ = binary.Write(wbuf, binary.LittleEndian, int16(-2))
+	_ = binary.Write(wbuf, binary.LittleEndian, int32(-3))
+	_ = binary.Write(wbuf, binary.LittleEndian, int64(-4))
+	_ = binary.Write(wbuf, binary.BigEndian, int16(-2))
+	_ = binary.Write(wbuf, binary.BigEndian, int32(-3))
+	_ = binary.Write(wbuf, binary.BigEndian, int64(-4))
+	_ = binary.Write(wbuf, binary.LittleEndian, math.Float32bits(1.5))
+	_ = binary.Write(wbuf, binary.BigEndian, math.Float32bits(1.5))
+	_ = binary.Write(wbuf, binary.LittleEndian, math.Float64bits(2.5))
+	_ = binary.Write(wbuf, binary.BigEndian, math.Float64bits(2.5))
+	want := wbuf.Bytes()
+
+	if !bytes.Equal(got, want) {
+		t.Fatalf("Expected:\n%x\nGot:\n%x", want, got)
+	}
+}
+
+func TestWriter_WithByteOrder(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf)
+	w.WithByteOrder(binary.BigEndian)
+
+	w.WriteUint16(1)
+	w.WriteUint32(2)
+	w.WriteUint64(3)
+	w.WriteInt16(-1)
+	w.WriteInt32(-1)
+	w.WriteInt64(-1)
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	var want []byte
+	buf2 := new(bytes.Buffer)
+	_ = binary.Write(buf2, binary.BigEndian, uint16(1))
+	_ = binary.Write(buf2, binary.BigEndian, uint32(2))
+	_ = binary.Write(buf2, binary.BigEndian, uint64(3))
+	_ = binary.Write(buf2, binary.BigEndian, int16(-1))
+	_ = binary.Write(buf2, binary.BigEndian, int32(-1))
+	_ = binary.Write(buf2, binary.BigEndian, int64(-1))
+	want = buf2.Bytes()
+
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("Expected:\n%x\nGot:\n%x", want, buf.Bytes())
+	}
+}
+
+func TestWriter_Write_Varint(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := New(buf)
+
+	w.WriteUvarint(300)
+	w.WriteVarint(-300)
+	w.WriteUvarintSlice([]uint64{1, 2, 300})
+
+	w.FlushInterim()
+	if err := w.Err(); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	wbuf := make([]byte, 0, 32)
+	wbuf = binary.AppendUvarint(wbuf, 300)
+	wbuf = binary.AppendVarint(wbuf, -300)
+	wbuf = binary.AppendUvarint(wbuf, 1)
+	wbuf = binary.AppendUvarint(wbuf, 2)
+	wbuf = binary.AppendUvarint(wbuf, 300)
+
+	if !bytes.Equal(buf.Bytes(), wbuf) {
+		t.Fatalf("Expected:\n%x\nGot:\n%x", wbuf, buf.Bytes())
+	}
+}
+
 func TestWriter_Write_Pending_Error(t *testing.T) {
 	// Test that we do not write anything after an error
 
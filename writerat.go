@@ -0,0 +1,287 @@
+package easywriter
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// errScratchTooSmall is set via Err when WithScratch is given a buffer
+// shorter than minScratchLen, which is too small for the largest fixed-size
+// binary write (WriteUint64LE/BE, WriteFloat64LE/BE).
+var errScratchTooSmall = errors.New("easywriter: scratch buffer must be at least 8 bytes long")
+
+// minScratchLen is the smallest scratch buffer WithScratch accepts, enough
+// to stage a uint64/float64 write.
+const minScratchLen = 8
+
+// WriterAt wraps an io.WriterAt and offers the same delayed-error binary and
+// text writing helpers as Writer, except every method takes an explicit
+// offset since there is no sequential cursor and no bufio buffer between it
+// and the destination.
+type WriterAt struct {
+	w       io.WriterAt
+	err     error
+	scratch []byte
+	own     [64]byte
+}
+
+// NewWriterAt constructs a WriterAt from an io.WriterAt, using an internally
+// owned scratch buffer for staging binary writes.
+func NewWriterAt(w io.WriterAt) *WriterAt {
+	a := &WriterAt{w: w}
+	a.scratch = a.own[:]
+	return a
+}
+
+// WithScratch replaces the scratch buffer used to stage binary writes before
+// they are handed to WriteAt. It must be at least 8 bytes long; if it is
+// not, Err latches errScratchTooSmall instead of the buffer being used, so
+// later writes cannot overrun it. It returns the WriterAt so it can be
+// chained onto NewWriterAt.
+func (a *WriterAt) WithScratch(buf []byte) *WriterAt {
+	if a.err != nil {
+		return a
+	}
+	if len(buf) < minScratchLen {
+		a.err = fmt.Errorf("%w: got %d", errScratchTooSmall, len(buf))
+		return a
+	}
+	a.scratch = buf
+	return a
+}
+
+// Err returns the current error, if any. Reading the error does not reset it.
+func (a *WriterAt) Err() error {
+	return a.err
+}
+
+// ResetErr resets the error to nil. You should never need this.
+func (a *WriterAt) ResetErr() {
+	a.err = nil
+}
+
+// WriteBytes writes p at offset off, without returning an error.
+func (a *WriterAt) WriteBytes(off int64, p []byte) {
+	if a.err != nil {
+		return
+	}
+	_, a.err = a.w.WriteAt(p, off)
+}
+
+// WriteByte writes a single byte at offset off, without returning an error.
+func (a *WriterAt) WriteByte(off int64, c byte) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:1]
+	t[0] = c
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteString writes s at offset off, without returning an error.
+func (a *WriterAt) WriteString(off int64, s string) {
+	if a.err != nil {
+		return
+	}
+	_, a.err = a.w.WriteAt([]byte(s), off)
+}
+
+// WriteDecimal writes num in decimal text representation at offset off,
+// without returning an error.
+func (a *WriterAt) WriteDecimal(off int64, num int) {
+	a.WriteNumber64(off, int64(num), 10)
+}
+
+// WriteNumber writes num in text representation with given base at offset
+// off, without returning an error.
+func (a *WriterAt) WriteNumber(off int64, num, base int) {
+	a.WriteNumber64(off, int64(num), base)
+}
+
+// WriteUnsignedNumber writes num in text representation with given base at
+// offset off, without returning an error.
+func (a *WriterAt) WriteUnsignedNumber(off int64, num uint, base int) {
+	a.WriteUnsignedNumber64(off, uint64(num), base)
+}
+
+// WriteNumber64 writes num in text representation with given base at offset
+// off, without returning an error.
+func (a *WriterAt) WriteNumber64(off int64, num int64, base int) {
+	if a.err != nil {
+		return
+	}
+	t := strconv.AppendInt(a.scratch[:0], num, base)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUnsignedNumber64 writes num in text representation with given base at
+// offset off, without returning an error.
+func (a *WriterAt) WriteUnsignedNumber64(off int64, num uint64, base int) {
+	if a.err != nil {
+		return
+	}
+	t := strconv.AppendUint(a.scratch[:0], num, base)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUint8 writes v as a single byte at offset off.
+func (a *WriterAt) WriteUint8(off int64, v uint8) {
+	a.WriteByte(off, v)
+}
+
+// WriteInt8 writes v as a single byte at offset off.
+func (a *WriterAt) WriteInt8(off int64, v int8) {
+	a.WriteByte(off, byte(v))
+}
+
+// WriteBoolByte writes v as a single byte at offset off, 1 for true and 0
+// for false.
+func (a *WriterAt) WriteBoolByte(off int64, v bool) {
+	if v {
+		a.WriteByte(off, 1)
+	} else {
+		a.WriteByte(off, 0)
+	}
+}
+
+// WriteUint16LE writes v in binary with Little Endian order at offset off.
+func (a *WriterAt) WriteUint16LE(off int64, v uint16) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:2]
+	binary.LittleEndian.PutUint16(t, v)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUint32LE writes v in binary with Little Endian order at offset off.
+func (a *WriterAt) WriteUint32LE(off int64, v uint32) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:4]
+	binary.LittleEndian.PutUint32(t, v)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUint64LE writes v in binary with Little Endian order at offset off.
+func (a *WriterAt) WriteUint64LE(off int64, v uint64) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:8]
+	binary.LittleEndian.PutUint64(t, v)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUint16BE writes v in binary with Big Endian order at offset off.
+func (a *WriterAt) WriteUint16BE(off int64, v uint16) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:2]
+	binary.BigEndian.PutUint16(t, v)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUint32BE writes v in binary with Big Endian order at offset off.
+func (a *WriterAt) WriteUint32BE(off int64, v uint32) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:4]
+	binary.BigEndian.PutUint32(t, v)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteUint64BE writes v in binary with Big Endian order at offset off.
+func (a *WriterAt) WriteUint64BE(off int64, v uint64) {
+	if a.err != nil {
+		return
+	}
+	t := a.scratch[:8]
+	binary.BigEndian.PutUint64(t, v)
+	_, a.err = a.w.WriteAt(t, off)
+}
+
+// WriteInt16LE writes v in binary with Little Endian order at offset off.
+func (a *WriterAt) WriteInt16LE(off int64, v int16) {
+	a.WriteUint16LE(off, uint16(v))
+}
+
+// WriteInt32LE writes v in binary with Little Endian order at offset off.
+func (a *WriterAt) WriteInt32LE(off int64, v int32) {
+	a.WriteUint32LE(off, uint32(v))
+}
+
+// WriteInt64LE writes v in binary with Little Endian order at offset off.
+func (a *WriterAt) WriteInt64LE(off int64, v int64) {
+	a.WriteUint64LE(off, uint64(v))
+}
+
+// WriteInt16BE writes v in binary with Big Endian order at offset off.
+func (a *WriterAt) WriteInt16BE(off int64, v int16) {
+	a.WriteUint16BE(off, uint16(v))
+}
+
+// WriteInt32BE writes v in binary with Big Endian order at offset off.
+func (a *WriterAt) WriteInt32BE(off int64, v int32) {
+	a.WriteUint32BE(off, uint32(v))
+}
+
+// WriteInt64BE writes v in binary with Big Endian order at offset off.
+func (a *WriterAt) WriteInt64BE(off int64, v int64) {
+	a.WriteUint64BE(off, uint64(v))
+}
+
+// WriteFloat32LE writes v in IEEE 754 binary representation with Little
+// Endian byte order at offset off.
+func (a *WriterAt) WriteFloat32LE(off int64, v float32) {
+	a.WriteUint32LE(off, math.Float32bits(v))
+}
+
+// WriteFloat32BE writes v in IEEE 754 binary representation with Big Endian
+// byte order at offset off.
+func (a *WriterAt) WriteFloat32BE(off int64, v float32) {
+	a.WriteUint32BE(off, math.Float32bits(v))
+}
+
+// WriteFloat64LE writes v in IEEE 754 binary representation with Little
+// Endian byte order at offset off.
+func (a *WriterAt) WriteFloat64LE(off int64, v float64) {
+	a.WriteUint64LE(off, math.Float64bits(v))
+}
+
+// WriteFloat64BE writes v in IEEE 754 binary representation with Big Endian
+// byte order at offset off.
+func (a *WriterAt) WriteFloat64BE(off int64, v float64) {
+	a.WriteUint64BE(off, math.Float64bits(v))
+}
+
+// sectionWriter adapts a WriterAt's destination plus a fixed base offset
+// into a plain io.Writer with a private, monotonically increasing cursor,
+// so Section can hand back a regular streaming Writer.
+type sectionWriter struct {
+	w      io.WriterAt
+	base   int64
+	cursor int64
+}
+
+func (s *sectionWriter) Write(p []byte) (int, error) {
+	n, err := s.w.WriteAt(p, s.base+s.cursor)
+	s.cursor += int64(n)
+	return n, err
+}
+
+// Section returns a streaming Writer whose flushes land at sequentially
+// increasing offsets starting at off. This lets callers writing fixed-layout
+// file formats mix ordinary sequential writing with post-hoc patching via
+// WriterAt, e.g. computing a checksum once a section is done and writing it
+// back to an earlier offset.
+func (a *WriterAt) Section(off int64) *Writer {
+	return New(&sectionWriter{w: a.w, base: off})
+}
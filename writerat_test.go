@@ -0,0 +1,126 @@
+package easywriter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"testing"
+)
+
+// fixedBufferAt is a minimal in-memory io.WriterAt backed by a fixed-size
+// byte slice, used to test WriterAt without touching the filesystem.
+type fixedBufferAt struct {
+	buf []byte
+}
+
+func (f *fixedBufferAt) WriteAt(p []byte, off int64) (int, error) {
+	end := int(off) + len(p)
+	if end > len(f.buf) {
+		return 0, io.ErrShortBuffer
+	}
+	copy(f.buf[off:end], p)
+	return len(p), nil
+}
+
+func TestWriterAt_BufferBacked(t *testing.T) {
+	dst := &fixedBufferAt{buf: make([]byte, 32)}
+	a := NewWriterAt(dst)
+
+	a.WriteUint32BE(0, 0xdeadbeef)
+	a.WriteString(4, "hi")
+	a.WriteBoolByte(6, true)
+	a.WriteFloat32LE(8, 1.5)
+
+	if err := a.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := make([]byte, 32)
+	binary.BigEndian.PutUint32(want[0:4], 0xdeadbeef)
+	copy(want[4:6], "hi")
+	want[6] = 1
+	binary.LittleEndian.PutUint32(want[8:12], math.Float32bits(1.5))
+
+	if !bytes.Equal(dst.buf, want) {
+		t.Fatalf("expected %x, got %x", want, dst.buf)
+	}
+}
+
+func TestWriterAt_Error(t *testing.T) {
+	dst := &fixedBufferAt{buf: make([]byte, 2)}
+	a := NewWriterAt(dst)
+
+	a.WriteUint32BE(0, 1) // does not fit, latches an error
+	if a.Err() == nil {
+		t.Fatal("expected an error")
+	}
+	a.WriteByte(0, 'x') // should be a no-op now
+	if !bytes.Equal(dst.buf, []byte{0, 0}) {
+		t.Fatalf("expected no writes after error, got %x", dst.buf)
+	}
+}
+
+func TestWriterAt_WithScratch_TooSmall(t *testing.T) {
+	dst := &fixedBufferAt{buf: make([]byte, 16)}
+	a := NewWriterAt(dst).WithScratch(make([]byte, 4))
+
+	if !errors.Is(a.Err(), errScratchTooSmall) {
+		t.Fatalf("expected errScratchTooSmall, got %v", a.Err())
+	}
+
+	a.WriteUint64BE(0, 1) // should be a no-op now
+	if !bytes.Equal(dst.buf, make([]byte, 16)) {
+		t.Fatalf("expected no writes after error, got %x", dst.buf)
+	}
+}
+
+func TestWriterAt_Section(t *testing.T) {
+	dst := &fixedBufferAt{buf: make([]byte, 16)}
+	a := NewWriterAt(dst)
+
+	s := a.Section(4)
+	s.WriteString("hello")
+	s.FlushInterim()
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// post-hoc patch, as with checksum fixups in fixed-layout file formats
+	a.WriteByte(0, byte(len("hello")))
+
+	want := make([]byte, 16)
+	want[0] = 5
+	copy(want[4:9], "hello")
+	if !bytes.Equal(dst.buf, want) {
+		t.Fatalf("expected %x, got %x", want, dst.buf)
+	}
+}
+
+func TestWriterAt_File(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "easywriter-writerat-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	a := NewWriterAt(f)
+	a.WriteUint64BE(0, 0x0102030405060708)
+	a.WriteString(8, "tail")
+	if err := a.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]byte, 12)
+	if _, err := f.ReadAt(got, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	want = append(want, "tail"...)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("expected %x, got %x", want, got)
+	}
+}